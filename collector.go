@@ -0,0 +1,108 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	scrapeDurationDesc = prometheus.NewDesc(
+		"envirobly_scrape_collector_duration_seconds",
+		"Duration of a collector's scrape",
+		[]string{"collector"}, nil,
+	)
+	scrapeSuccessDesc = prometheus.NewDesc(
+		"envirobly_scrape_collector_success",
+		"Whether a collector's scrape succeeded",
+		[]string{"collector"}, nil,
+	)
+)
+
+// Collector is implemented by every metrics source this binary knows how to
+// scrape. Update is invoked once per Prometheus scrape and should push any
+// metrics it gathers onto ch, typically via prometheus.NewConstMetric.
+type Collector interface {
+	Update(ch chan<- prometheus.Metric) error
+}
+
+type collectorFactory func() (Collector, error)
+
+var (
+	factories      = make(map[string]collectorFactory)
+	collectorFlags = make(map[string]*bool)
+)
+
+// registerCollector adds a collector to the registry and wires up its
+// --collector.<name> enable/disable flag, mirroring node_exporter's
+// collector.Factories pattern. Collector files call this from an init().
+func registerCollector(name string, isDefaultEnabled bool, factory collectorFactory) {
+	flagName := fmt.Sprintf("collector.%s", name)
+	flagHelp := fmt.Sprintf("Enable the %s collector (default: %t).", name, isDefaultEnabled)
+	collectorFlags[name] = flag.Bool(flagName, isDefaultEnabled, flagHelp)
+	factories[name] = factory
+}
+
+// NodeCollector implements prometheus.Collector by fanning out to every
+// enabled Collector. It describes nothing up front (an "unchecked"
+// collector, same as node_exporter) since metrics are built at collect
+// time via NewConstMetric.
+type NodeCollector struct {
+	collectors map[string]Collector
+}
+
+// NewNodeCollector instantiates every collector enabled via its
+// --collector.<name> flag.
+func NewNodeCollector() (*NodeCollector, error) {
+	collectors := make(map[string]Collector)
+	for name, enabled := range collectorFlags {
+		if !*enabled {
+			continue
+		}
+		collector, err := factories[name]()
+		if err != nil {
+			return nil, fmt.Errorf("couldn't create collector %s: %w", name, err)
+		}
+		collectors[name] = collector
+	}
+	return &NodeCollector{collectors: collectors}, nil
+}
+
+// Describe implements prometheus.Collector.
+func (n *NodeCollector) Describe(ch chan<- *prometheus.Desc) {}
+
+// Collect implements prometheus.Collector, running every enabled collector
+// concurrently so a slow or failing one doesn't hold up the others.
+func (n *NodeCollector) Collect(ch chan<- prometheus.Metric) {
+	wg := sync.WaitGroup{}
+	wg.Add(len(n.collectors))
+	for name, c := range n.collectors {
+		go func(name string, c Collector) {
+			defer wg.Done()
+			execute(name, c, ch)
+		}(name, c)
+	}
+	wg.Wait()
+}
+
+// execute runs a single collector's Update and, node_exporter style, records
+// how long it took and whether it succeeded so a slow or broken collector
+// shows up in the scrape itself rather than as a silent gap in the data.
+func execute(name string, c Collector, ch chan<- prometheus.Metric) {
+	start := time.Now()
+	err := c.Update(ch)
+	duration := time.Since(start)
+
+	success := 1.0
+	if err != nil {
+		log.Printf("Error collecting metrics from collector %s: %v", name, err)
+		success = 0
+	}
+
+	ch <- prometheus.MustNewConstMetric(scrapeDurationDesc, prometheus.GaugeValue, duration.Seconds(), name)
+	ch <- prometheus.MustNewConstMetric(scrapeSuccessDesc, prometheus.GaugeValue, success, name)
+}
@@ -0,0 +1,36 @@
+package main
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/shirou/gopsutil/host"
+)
+
+type boottimeCollector struct {
+	boottime *prometheus.Desc
+}
+
+func init() {
+	registerCollector("boottime", true, NewBoottimeCollector)
+}
+
+// NewBoottimeCollector returns a Collector exposing the host's boot time
+// as a Unix timestamp.
+func NewBoottimeCollector() (Collector, error) {
+	return &boottimeCollector{
+		boottime: prometheus.NewDesc(
+			"envirobly_boot_time_seconds",
+			"Unix time of last boot, in whole seconds",
+			nil, nil,
+		),
+	}, nil
+}
+
+func (c *boottimeCollector) Update(ch chan<- prometheus.Metric) error {
+	bootTime, err := host.BootTime()
+	if err != nil {
+		return err
+	}
+
+	ch <- prometheus.MustNewConstMetric(c.boottime, prometheus.GaugeValue, float64(bootTime))
+	return nil
+}
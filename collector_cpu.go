@@ -0,0 +1,52 @@
+package main
+
+import (
+	"strconv"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/shirou/gopsutil/cpu"
+)
+
+type cpuCollector struct {
+	secondsTotal *prometheus.Desc
+}
+
+func init() {
+	registerCollector("cpu", true, NewCPUCollector)
+}
+
+// NewCPUCollector returns a Collector exposing per-core, per-mode CPU time,
+// node_exporter's node_cpu_seconds_total style.
+func NewCPUCollector() (Collector, error) {
+	return &cpuCollector{
+		secondsTotal: prometheus.NewDesc(
+			"envirobly_cpu_seconds_total",
+			"Seconds the CPU spent in each mode",
+			[]string{"cpu", "mode"}, nil,
+		),
+	}, nil
+}
+
+func (c *cpuCollector) Update(ch chan<- prometheus.Metric) error {
+	times, err := cpu.Times(true)
+	if err != nil {
+		return err
+	}
+
+	for i, t := range times {
+		cpuLabel := strconv.Itoa(i)
+		c.emit(ch, cpuLabel, "user", t.User)
+		c.emit(ch, cpuLabel, "system", t.System)
+		c.emit(ch, cpuLabel, "idle", t.Idle)
+		c.emit(ch, cpuLabel, "nice", t.Nice)
+		c.emit(ch, cpuLabel, "iowait", t.Iowait)
+		c.emit(ch, cpuLabel, "irq", t.Irq)
+		c.emit(ch, cpuLabel, "softirq", t.Softirq)
+		c.emit(ch, cpuLabel, "steal", t.Steal)
+	}
+	return nil
+}
+
+func (c *cpuCollector) emit(ch chan<- prometheus.Metric, cpuLabel, mode string, seconds float64) {
+	ch <- prometheus.MustNewConstMetric(c.secondsTotal, prometheus.CounterValue, seconds, cpuLabel, mode)
+}
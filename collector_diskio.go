@@ -0,0 +1,43 @@
+package main
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/shirou/gopsutil/disk"
+)
+
+type diskioCollector struct {
+	readBytes  *prometheus.Desc
+	writeBytes *prometheus.Desc
+	readOps    *prometheus.Desc
+	writeOps   *prometheus.Desc
+}
+
+func init() {
+	registerCollector("diskio", true, NewDiskioCollector)
+}
+
+// NewDiskioCollector returns a Collector exposing per-device disk I/O
+// counters.
+func NewDiskioCollector() (Collector, error) {
+	return &diskioCollector{
+		readBytes:  prometheus.NewDesc("envirobly_diskio_read_bytes_total", "Total bytes read from the device", []string{"device"}, nil),
+		writeBytes: prometheus.NewDesc("envirobly_diskio_written_bytes_total", "Total bytes written to the device", []string{"device"}, nil),
+		readOps:    prometheus.NewDesc("envirobly_diskio_reads_total", "Total reads completed on the device", []string{"device"}, nil),
+		writeOps:   prometheus.NewDesc("envirobly_diskio_writes_total", "Total writes completed on the device", []string{"device"}, nil),
+	}, nil
+}
+
+func (c *diskioCollector) Update(ch chan<- prometheus.Metric) error {
+	counters, err := disk.IOCounters()
+	if err != nil {
+		return err
+	}
+
+	for device, stat := range counters {
+		ch <- prometheus.MustNewConstMetric(c.readBytes, prometheus.CounterValue, float64(stat.ReadBytes), device)
+		ch <- prometheus.MustNewConstMetric(c.writeBytes, prometheus.CounterValue, float64(stat.WriteBytes), device)
+		ch <- prometheus.MustNewConstMetric(c.readOps, prometheus.CounterValue, float64(stat.ReadCount), device)
+		ch <- prometheus.MustNewConstMetric(c.writeOps, prometheus.CounterValue, float64(stat.WriteCount), device)
+	}
+	return nil
+}
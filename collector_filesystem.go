@@ -0,0 +1,86 @@
+package main
+
+import (
+	"flag"
+	"log"
+	"regexp"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/shirou/gopsutil/disk"
+)
+
+var (
+	fsMountPointsExcludeFlag = flag.String(
+		"collector.filesystem.mount-points-exclude",
+		`^/(boot/efi|var/envirobly/zpools|var/lib/docker/volumes)($|/)`,
+		"Regexp of mount points to exclude from filesystem collection.",
+	)
+	fsTypesExcludeFlag = flag.String(
+		"collector.filesystem.fs-types-exclude",
+		`^(autofs|binfmt_misc|bpf|cgroup2?|configfs|debugfs|devpts|devtmpfs|fusectl|hugetlbfs|mqueue|nsfs|overlay|proc|pstore|rpc_pipefs|securityfs|squashfs|sysfs|tmpfs|tracefs)$`,
+		"Regexp of filesystem types to exclude from filesystem collection.",
+	)
+)
+
+type filesystemCollector struct {
+	usage            *prometheus.Desc
+	mountPointFilter *regexp.Regexp
+	fsTypeFilter     *regexp.Regexp
+}
+
+func init() {
+	registerCollector("filesystem", true, NewFilesystemCollector)
+}
+
+// NewFilesystemCollector returns a Collector exposing per-mountpoint
+// filesystem utilization.
+func NewFilesystemCollector() (Collector, error) {
+	mountPointFilter, err := regexp.Compile(*fsMountPointsExcludeFlag)
+	if err != nil {
+		return nil, err
+	}
+
+	fsTypeFilter, err := regexp.Compile(*fsTypesExcludeFlag)
+	if err != nil {
+		return nil, err
+	}
+
+	return &filesystemCollector{
+		usage: prometheus.NewDesc(
+			"envirobly_filesystem_usage_percent",
+			"Filesystem utilization in percent",
+			[]string{"filesystem", "mountpoint"}, nil,
+		),
+		mountPointFilter: mountPointFilter,
+		fsTypeFilter:     fsTypeFilter,
+	}, nil
+}
+
+func (c *filesystemCollector) Update(ch chan<- prometheus.Metric) error {
+	partitions, err := disk.Partitions(false)
+	if err != nil {
+		return err
+	}
+
+	for _, partition := range partitions {
+		if c.mountPointFilter.MatchString(partition.Mountpoint) {
+			continue
+		}
+		if c.fsTypeFilter.MatchString(partition.Fstype) {
+			continue
+		}
+
+		usageStat, err := disk.Usage(partition.Mountpoint)
+		if err != nil {
+			log.Printf("Error collecting filesystem usage for %s: %v", partition.Mountpoint, err)
+			continue
+		}
+
+		ch <- prometheus.MustNewConstMetric(
+			c.usage, prometheus.GaugeValue,
+			roundToTwoDecimals(usageStat.UsedPercent),
+			partition.Device, partition.Mountpoint,
+		)
+	}
+	return nil
+}
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/shirou/gopsutil/load"
+)
+
+type loadavgCollector struct {
+	load1  *prometheus.Desc
+	load5  *prometheus.Desc
+	load15 *prometheus.Desc
+}
+
+func init() {
+	registerCollector("loadavg", true, NewLoadavgCollector)
+}
+
+// NewLoadavgCollector returns a Collector exposing the 1/5/15 minute load
+// averages.
+func NewLoadavgCollector() (Collector, error) {
+	return &loadavgCollector{
+		load1:  prometheus.NewDesc("envirobly_load1", "1m load average", nil, nil),
+		load5:  prometheus.NewDesc("envirobly_load5", "5m load average", nil, nil),
+		load15: prometheus.NewDesc("envirobly_load15", "15m load average", nil, nil),
+	}, nil
+}
+
+func (c *loadavgCollector) Update(ch chan<- prometheus.Metric) error {
+	avg, err := load.Avg()
+	if err != nil {
+		return err
+	}
+
+	ch <- prometheus.MustNewConstMetric(c.load1, prometheus.GaugeValue, avg.Load1)
+	ch <- prometheus.MustNewConstMetric(c.load5, prometheus.GaugeValue, avg.Load5)
+	ch <- prometheus.MustNewConstMetric(c.load15, prometheus.GaugeValue, avg.Load15)
+	return nil
+}
@@ -0,0 +1,142 @@
+package main
+
+import (
+	"flag"
+	"regexp"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/shirou/gopsutil/net"
+)
+
+var (
+	netdevDeviceIncludeFlag = flag.String(
+		"collector.netdev.device-include",
+		"^ens",
+		"Regexp of net devices to include. Takes priority over --collector.netdev.device-exclude when non-empty.",
+	)
+	netdevDeviceExcludeFlag = flag.String(
+		"collector.netdev.device-exclude",
+		"",
+		"Regexp of net devices to exclude. Only used when --collector.netdev.device-include is empty.",
+	)
+)
+
+type netdevCollector struct {
+	bytesSent   *prometheus.Desc
+	bytesRecv   *prometheus.Desc
+	packetsSent *prometheus.Desc
+	packetsRecv *prometheus.Desc
+	errorsSent  *prometheus.Desc
+	errorsRecv  *prometheus.Desc
+	droppedSent *prometheus.Desc
+	droppedRecv *prometheus.Desc
+
+	deviceInclude *regexp.Regexp
+	deviceExclude *regexp.Regexp
+}
+
+func init() {
+	registerCollector("net", true, NewNetdevCollector)
+}
+
+// NewNetdevCollector returns a Collector exposing per-interface network
+// throughput counters.
+func NewNetdevCollector() (Collector, error) {
+	var deviceInclude *regexp.Regexp
+	if *netdevDeviceIncludeFlag != "" {
+		re, err := regexp.Compile(*netdevDeviceIncludeFlag)
+		if err != nil {
+			return nil, err
+		}
+		deviceInclude = re
+	}
+
+	var deviceExclude *regexp.Regexp
+	if *netdevDeviceExcludeFlag != "" {
+		re, err := regexp.Compile(*netdevDeviceExcludeFlag)
+		if err != nil {
+			return nil, err
+		}
+		deviceExclude = re
+	}
+
+	return &netdevCollector{
+		bytesSent: prometheus.NewDesc(
+			"envirobly_network_bytes_sent_total",
+			"Total bytes transmitted on network interfaces",
+			[]string{"interface"}, nil,
+		),
+		bytesRecv: prometheus.NewDesc(
+			"envirobly_network_bytes_recv_total",
+			"Total bytes received on network interfaces",
+			[]string{"interface"}, nil,
+		),
+		packetsSent: prometheus.NewDesc(
+			"envirobly_network_packets_sent_total",
+			"Total packets transmitted on network interfaces",
+			[]string{"interface"}, nil,
+		),
+		packetsRecv: prometheus.NewDesc(
+			"envirobly_network_packets_recv_total",
+			"Total packets received on network interfaces",
+			[]string{"interface"}, nil,
+		),
+		errorsSent: prometheus.NewDesc(
+			"envirobly_network_errors_sent_total",
+			"Total transmit errors on network interfaces",
+			[]string{"interface"}, nil,
+		),
+		errorsRecv: prometheus.NewDesc(
+			"envirobly_network_errors_recv_total",
+			"Total receive errors on network interfaces",
+			[]string{"interface"}, nil,
+		),
+		droppedSent: prometheus.NewDesc(
+			"envirobly_network_dropped_sent_total",
+			"Total transmitted packets dropped on network interfaces",
+			[]string{"interface"}, nil,
+		),
+		droppedRecv: prometheus.NewDesc(
+			"envirobly_network_dropped_recv_total",
+			"Total received packets dropped on network interfaces",
+			[]string{"interface"}, nil,
+		),
+		deviceInclude: deviceInclude,
+		deviceExclude: deviceExclude,
+	}, nil
+}
+
+// isCollectedInterface reports whether a network interface should be
+// scraped, per --collector.netdev.device-include/-exclude.
+func (c *netdevCollector) isCollectedInterface(name string) bool {
+	if c.deviceInclude != nil {
+		return c.deviceInclude.MatchString(name)
+	}
+	if c.deviceExclude != nil {
+		return !c.deviceExclude.MatchString(name)
+	}
+	return true
+}
+
+func (c *netdevCollector) Update(ch chan<- prometheus.Metric) error {
+	netIOStats, err := net.IOCounters(true)
+	if err != nil {
+		return err
+	}
+
+	for _, stat := range netIOStats {
+		if !c.isCollectedInterface(stat.Name) {
+			continue
+		}
+
+		ch <- prometheus.MustNewConstMetric(c.bytesSent, prometheus.CounterValue, float64(stat.BytesSent), stat.Name)
+		ch <- prometheus.MustNewConstMetric(c.bytesRecv, prometheus.CounterValue, float64(stat.BytesRecv), stat.Name)
+		ch <- prometheus.MustNewConstMetric(c.packetsSent, prometheus.CounterValue, float64(stat.PacketsSent), stat.Name)
+		ch <- prometheus.MustNewConstMetric(c.packetsRecv, prometheus.CounterValue, float64(stat.PacketsRecv), stat.Name)
+		ch <- prometheus.MustNewConstMetric(c.errorsSent, prometheus.CounterValue, float64(stat.Errout), stat.Name)
+		ch <- prometheus.MustNewConstMetric(c.errorsRecv, prometheus.CounterValue, float64(stat.Errin), stat.Name)
+		ch <- prometheus.MustNewConstMetric(c.droppedSent, prometheus.CounterValue, float64(stat.Dropout), stat.Name)
+		ch <- prometheus.MustNewConstMetric(c.droppedRecv, prometheus.CounterValue, float64(stat.Dropin), stat.Name)
+	}
+	return nil
+}
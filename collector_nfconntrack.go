@@ -0,0 +1,58 @@
+package main
+
+import (
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	nfConntrackCountFile = "/proc/sys/net/netfilter/nf_conntrack_count"
+	nfConntrackMaxFile   = "/proc/sys/net/netfilter/nf_conntrack_max"
+)
+
+type nfconntrackCollector struct {
+	entries    *prometheus.Desc
+	entriesMax *prometheus.Desc
+}
+
+func init() {
+	registerCollector("nfconntrack", true, NewNfConntrackCollector)
+}
+
+// NewNfConntrackCollector returns a Collector exposing the netfilter
+// connection tracking table size and limit.
+func NewNfConntrackCollector() (Collector, error) {
+	return &nfconntrackCollector{
+		entries:    prometheus.NewDesc("envirobly_nf_conntrack_entries", "Number of currently tracked connections", nil, nil),
+		entriesMax: prometheus.NewDesc("envirobly_nf_conntrack_entries_limit", "Maximum size of the nf_conntrack table", nil, nil),
+	}, nil
+}
+
+func readNfConntrackFile(path string) (float64, error) {
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return 0, err
+	}
+
+	return strconv.ParseFloat(strings.TrimSpace(string(contents)), 64)
+}
+
+func (c *nfconntrackCollector) Update(ch chan<- prometheus.Metric) error {
+	count, err := readNfConntrackFile(nfConntrackCountFile)
+	if err != nil {
+		// nf_conntrack isn't loaded on every host (e.g. inside containers).
+		return nil
+	}
+	ch <- prometheus.MustNewConstMetric(c.entries, prometheus.GaugeValue, count)
+
+	max, err := readNfConntrackFile(nfConntrackMaxFile)
+	if err != nil {
+		return nil
+	}
+	ch <- prometheus.MustNewConstMetric(c.entriesMax, prometheus.GaugeValue, max)
+
+	return nil
+}
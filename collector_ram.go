@@ -0,0 +1,35 @@
+package main
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/shirou/gopsutil/mem"
+)
+
+type ramCollector struct {
+	usage *prometheus.Desc
+}
+
+func init() {
+	registerCollector("ram", true, NewRAMCollector)
+}
+
+// NewRAMCollector returns a Collector exposing overall RAM utilization.
+func NewRAMCollector() (Collector, error) {
+	return &ramCollector{
+		usage: prometheus.NewDesc(
+			"envirobly_ram_usage_percent",
+			"Total RAM utilization in percent",
+			nil, nil,
+		),
+	}, nil
+}
+
+func (c *ramCollector) Update(ch chan<- prometheus.Metric) error {
+	vmStat, err := mem.VirtualMemory()
+	if err != nil {
+		return err
+	}
+
+	ch <- prometheus.MustNewConstMetric(c.usage, prometheus.GaugeValue, roundToTwoDecimals(vmStat.UsedPercent))
+	return nil
+}
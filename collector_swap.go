@@ -0,0 +1,35 @@
+package main
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/shirou/gopsutil/mem"
+)
+
+type swapCollector struct {
+	usage *prometheus.Desc
+}
+
+func init() {
+	registerCollector("swap", true, NewSwapCollector)
+}
+
+// NewSwapCollector returns a Collector exposing overall swap utilization.
+func NewSwapCollector() (Collector, error) {
+	return &swapCollector{
+		usage: prometheus.NewDesc(
+			"envirobly_swap_usage_percent",
+			"Total swap memory utilization in percent",
+			nil, nil,
+		),
+	}, nil
+}
+
+func (c *swapCollector) Update(ch chan<- prometheus.Metric) error {
+	swapStat, err := mem.SwapMemory()
+	if err != nil {
+		return err
+	}
+
+	ch <- prometheus.MustNewConstMetric(c.usage, prometheus.GaugeValue, roundToTwoDecimals(swapStat.UsedPercent))
+	return nil
+}
@@ -0,0 +1,169 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const zfsKstatPath = "/proc/spl/kstat/zfs"
+
+type zfsCollector struct {
+	up              *prometheus.Desc
+	arcHits         *prometheus.Desc
+	arcMisses       *prometheus.Desc
+	poolReadBytes   *prometheus.Desc
+	poolWriteBytes  *prometheus.Desc
+	poolReadOps     *prometheus.Desc
+	poolWriteOps    *prometheus.Desc
+	datasetReadOps  *prometheus.Desc
+	datasetWriteOps *prometheus.Desc
+	txgNumber       *prometheus.Desc
+	txgSyncTime     *prometheus.Desc
+}
+
+func init() {
+	registerCollector("zfs", true, NewZFSCollector)
+}
+
+// NewZFSCollector returns a Collector exposing ZFS pool and ARC statistics
+// read directly from /proc/spl/kstat/zfs, with no dependency on the zpool
+// CLI being installed or runnable as root.
+//
+// Per-dataset used/available space is not exposed: the objset-* kstat files
+// only carry I/O counters (reads/writes/nread/nwritten), not space
+// accounting, so that data isn't available without shelling out to
+// `zfs list` (the exact dependency this collector was written to avoid).
+// Transaction-group activity, unlike dataset space, *is* available natively
+// via the pool's txgs kstat and is exposed below. ARC hit/miss counters are
+// exposed as-is rather than a derived efficiency ratio, since that's
+// trivially computed in PromQL.
+func NewZFSCollector() (Collector, error) {
+	return &zfsCollector{
+		up: prometheus.NewDesc(
+			"envirobly_zfs_up",
+			"Whether ZFS kstat metrics are present (1) or the kstat tree is unavailable (0)",
+			nil, nil,
+		),
+		arcHits: prometheus.NewDesc(
+			"envirobly_zfs_arc_hits_total",
+			"Total ARC hits",
+			nil, nil,
+		),
+		arcMisses: prometheus.NewDesc(
+			"envirobly_zfs_arc_misses_total",
+			"Total ARC misses",
+			nil, nil,
+		),
+		poolReadBytes: prometheus.NewDesc(
+			"envirobly_zfs_pool_read_bytes_total",
+			"Total bytes read from the pool",
+			[]string{"pool"}, nil,
+		),
+		poolWriteBytes: prometheus.NewDesc(
+			"envirobly_zfs_pool_written_bytes_total",
+			"Total bytes written to the pool",
+			[]string{"pool"}, nil,
+		),
+		poolReadOps: prometheus.NewDesc(
+			"envirobly_zfs_pool_reads_total",
+			"Total read operations on the pool",
+			[]string{"pool"}, nil,
+		),
+		poolWriteOps: prometheus.NewDesc(
+			"envirobly_zfs_pool_writes_total",
+			"Total write operations on the pool",
+			[]string{"pool"}, nil,
+		),
+		datasetReadOps: prometheus.NewDesc(
+			"envirobly_zfs_dataset_reads_total",
+			"Total read operations on the dataset",
+			[]string{"pool", "objset"}, nil,
+		),
+		datasetWriteOps: prometheus.NewDesc(
+			"envirobly_zfs_dataset_writes_total",
+			"Total write operations on the dataset",
+			[]string{"pool", "objset"}, nil,
+		),
+		txgNumber: prometheus.NewDesc(
+			"envirobly_zfs_pool_txg_number",
+			"Number of the most recently completed transaction group",
+			[]string{"pool"}, nil,
+		),
+		txgSyncTime: prometheus.NewDesc(
+			"envirobly_zfs_pool_txg_sync_time_seconds",
+			"Time the most recently completed transaction group spent syncing",
+			[]string{"pool"}, nil,
+		),
+	}, nil
+}
+
+func (c *zfsCollector) Update(ch chan<- prometheus.Metric) error {
+	pools, err := c.pools()
+	if err != nil {
+		ch <- prometheus.MustNewConstMetric(c.up, prometheus.GaugeValue, 0)
+		return nil
+	}
+	ch <- prometheus.MustNewConstMetric(c.up, prometheus.GaugeValue, 1)
+
+	if arcstats, err := parseKstatNamed(filepath.Join(zfsKstatPath, "arcstats")); err == nil {
+		ch <- prometheus.MustNewConstMetric(c.arcHits, prometheus.CounterValue, float64(arcstats["hits"]))
+		ch <- prometheus.MustNewConstMetric(c.arcMisses, prometheus.CounterValue, float64(arcstats["misses"]))
+	}
+
+	for _, pool := range pools {
+		poolDir := filepath.Join(zfsKstatPath, pool)
+
+		if io, err := parseKstatIO(filepath.Join(poolDir, "io")); err == nil {
+			ch <- prometheus.MustNewConstMetric(c.poolReadBytes, prometheus.CounterValue, float64(io.NRead), pool)
+			ch <- prometheus.MustNewConstMetric(c.poolWriteBytes, prometheus.CounterValue, float64(io.NWritten), pool)
+			ch <- prometheus.MustNewConstMetric(c.poolReadOps, prometheus.CounterValue, float64(io.Reads), pool)
+			ch <- prometheus.MustNewConstMetric(c.poolWriteOps, prometheus.CounterValue, float64(io.Writes), pool)
+		}
+
+		if txg, err := parseKstatTxgs(filepath.Join(poolDir, "txgs")); err == nil {
+			ch <- prometheus.MustNewConstMetric(c.txgNumber, prometheus.CounterValue, float64(txg.Txg), pool)
+			ch <- prometheus.MustNewConstMetric(c.txgSyncTime, prometheus.GaugeValue, float64(txg.SyncTime)/1e9, pool)
+		}
+
+		entries, err := os.ReadDir(poolDir)
+		if err != nil {
+			continue
+		}
+		for _, entry := range entries {
+			if !strings.HasPrefix(entry.Name(), "objset-") {
+				continue
+			}
+
+			objset, err := parseKstatNamed(filepath.Join(poolDir, entry.Name()))
+			if err != nil {
+				continue
+			}
+
+			ch <- prometheus.MustNewConstMetric(c.datasetReadOps, prometheus.CounterValue, float64(objset["reads"]), pool, entry.Name())
+			ch <- prometheus.MustNewConstMetric(c.datasetWriteOps, prometheus.CounterValue, float64(objset["writes"]), pool, entry.Name())
+		}
+	}
+
+	return nil
+}
+
+// pools lists the ZFS pools with a kstat directory, i.e. every subdirectory
+// of /proc/spl/kstat/zfs; plain files such as the global "arcstats" are
+// skipped.
+func (c *zfsCollector) pools() ([]string, error) {
+	entries, err := os.ReadDir(zfsKstatPath)
+	if err != nil {
+		return nil, err
+	}
+
+	var pools []string
+	for _, entry := range entries {
+		if entry.IsDir() {
+			pools = append(pools, entry.Name())
+		}
+	}
+	return pools, nil
+}
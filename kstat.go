@@ -0,0 +1,189 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// parseKstatNamed parses a "named" kstat file (e.g. arcstats, or an
+// objset-* dataset file) of the form:
+//
+//	<module> <instance> <name> <class> <crtime> <snaptime>
+//	name                            type data
+//	hits                            4    123456
+//	...
+//
+// and returns the data column keyed by field name.
+func parseKstatNamed(path string) (map[string]uint64, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	values := make(map[string]uint64)
+
+	scanner := bufio.NewScanner(file)
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+		// Line 1 is the kstat header, line 2 is the "name type data" column header.
+		if lineNum <= 2 {
+			continue
+		}
+
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 3 {
+			continue
+		}
+
+		value, err := strconv.ParseUint(fields[2], 10, 64)
+		if err != nil {
+			continue
+		}
+		values[fields[0]] = value
+	}
+
+	return values, scanner.Err()
+}
+
+// kstatIO mirrors the fields of a ZFS pool's kstat io file.
+type kstatIO struct {
+	NRead    uint64
+	NWritten uint64
+	Reads    uint64
+	Writes   uint64
+}
+
+// parseKstatIO parses a pool's io kstat file, which holds a single row of
+// values under a column-name header line:
+//
+//	<module> <instance> <name> <class> <crtime> <snaptime>
+//	nread nwritten reads writes wtime wlentime wupdate rtime rlentime rupdate
+//	123   456      12    34     ...
+func parseKstatIO(path string) (*kstatIO, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	var header, data []string
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+		switch lineNum {
+		case 1:
+			continue
+		case 2:
+			header = strings.Fields(scanner.Text())
+		case 3:
+			data = strings.Fields(scanner.Text())
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(header) == 0 || len(data) != len(header) {
+		return nil, fmt.Errorf("kstat io: unexpected format in %s", path)
+	}
+
+	columns := make(map[string]uint64, len(header))
+	for i, name := range header {
+		value, err := strconv.ParseUint(data[i], 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		columns[name] = value
+	}
+
+	return &kstatIO{
+		NRead:    columns["nread"],
+		NWritten: columns["nwritten"],
+		Reads:    columns["reads"],
+		Writes:   columns["writes"],
+	}, nil
+}
+
+// kstatTxg mirrors one row of a ZFS pool's kstat txgs file: a completed
+// transaction group and how long it spent in each phase, in nanoseconds.
+type kstatTxg struct {
+	Txg      uint64
+	SyncTime uint64
+}
+
+// parseKstatTxgs parses a pool's txgs kstat file, a "list" style kstat with
+// one row per historical transaction group under a column-name header line:
+//
+//	<module> <instance> <name> <class> <crtime> <snaptime>
+//	txg birth state nread nwritten reads writes otime qtime wtime stime
+//	4886889 ... C ... 123456
+//	4886890 ... C ... 234567
+//
+// Unlike the single-row io kstat, rows accumulate as txgs progress. Rows for
+// txgs that haven't completed yet (any state other than 'C', e.g. 'S' for
+// syncing) are skipped, since fields like stime aren't final until the txg
+// completes, so the most recently completed txg is the last 'C' row.
+func parseKstatTxgs(path string) (*kstatTxg, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	var header []string
+	var lastRow []string
+	stateCol := -1
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+		fields := strings.Fields(scanner.Text())
+
+		switch {
+		case lineNum == 1:
+			continue
+		case lineNum == 2:
+			header = fields
+			for i, name := range header {
+				if name == "state" {
+					stateCol = i
+				}
+			}
+		default:
+			if len(fields) != len(header) {
+				continue
+			}
+			if stateCol >= 0 && fields[stateCol] != "C" {
+				continue
+			}
+			lastRow = fields
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if lastRow == nil {
+		return nil, fmt.Errorf("kstat txgs: no completed transaction groups in %s", path)
+	}
+
+	columns := make(map[string]string, len(header))
+	for i, name := range header {
+		columns[name] = lastRow[i]
+	}
+
+	txg, err := strconv.ParseUint(columns["txg"], 10, 64)
+	if err != nil {
+		return nil, err
+	}
+	syncTime, err := strconv.ParseUint(columns["stime"], 10, 64)
+	if err != nil {
+		return nil, err
+	}
+
+	return &kstatTxg{Txg: txg, SyncTime: syncTime}, nil
+}
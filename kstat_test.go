@@ -0,0 +1,84 @@
+package main
+
+import "testing"
+
+func TestParseKstatNamed(t *testing.T) {
+	values, err := parseKstatNamed("testdata/kstat/arcstats")
+	if err != nil {
+		t.Fatalf("parseKstatNamed: %v", err)
+	}
+
+	want := map[string]uint64{
+		"hits":             918273,
+		"misses":           4455,
+		"demand_data_hits": 100,
+	}
+	for key, wantValue := range want {
+		if got := values[key]; got != wantValue {
+			t.Errorf("values[%q] = %d, want %d", key, got, wantValue)
+		}
+	}
+}
+
+func TestParseKstatNamedSkipsNonNumericValues(t *testing.T) {
+	values, err := parseKstatNamed("testdata/kstat/tank/objset-0x33")
+	if err != nil {
+		t.Fatalf("parseKstatNamed: %v", err)
+	}
+
+	if _, ok := values["dataset_name"]; ok {
+		t.Errorf("expected non-numeric field %q to be skipped", "dataset_name")
+	}
+	if got, want := values["reads"], uint64(17); got != want {
+		t.Errorf("values[reads] = %d, want %d", got, want)
+	}
+	if got, want := values["writes"], uint64(42); got != want {
+		t.Errorf("values[writes] = %d, want %d", got, want)
+	}
+}
+
+func TestParseKstatIO(t *testing.T) {
+	io, err := parseKstatIO("testdata/kstat/tank/io")
+	if err != nil {
+		t.Fatalf("parseKstatIO: %v", err)
+	}
+
+	if io.NRead != 10240 {
+		t.Errorf("NRead = %d, want 10240", io.NRead)
+	}
+	if io.NWritten != 20480 {
+		t.Errorf("NWritten = %d, want 20480", io.NWritten)
+	}
+	if io.Reads != 12 {
+		t.Errorf("Reads = %d, want 12", io.Reads)
+	}
+	if io.Writes != 34 {
+		t.Errorf("Writes = %d, want 34", io.Writes)
+	}
+}
+
+func TestParseKstatIOMissingFile(t *testing.T) {
+	if _, err := parseKstatIO("testdata/kstat/does-not-exist"); err == nil {
+		t.Fatal("expected an error for a missing kstat file")
+	}
+}
+
+func TestParseKstatTxgs(t *testing.T) {
+	txg, err := parseKstatTxgs("testdata/kstat/tank/txgs")
+	if err != nil {
+		t.Fatalf("parseKstatTxgs: %v", err)
+	}
+
+	if txg.Txg != 4886890 {
+		t.Errorf("Txg = %d, want 4886890 (the last row)", txg.Txg)
+	}
+	if txg.SyncTime != 310000000 {
+		t.Errorf("SyncTime = %d, want 310000000", txg.SyncTime)
+	}
+}
+
+func TestParseKstatTxgsMissingFile(t *testing.T) {
+	if _, err := parseKstatTxgs("testdata/kstat/does-not-exist"); err == nil {
+		t.Fatal("expected an error for a missing kstat file")
+	}
+}
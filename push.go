@@ -0,0 +1,169 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/golang/snappy"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/prometheus/prompb"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	pushRemoteWriteURLFlag = flag.String(
+		"push.remote-write-url",
+		"",
+		"If set, periodically push the registry to this Prometheus Remote Write endpoint. /metrics is still served; push does not replace it.",
+	)
+	pushIntervalFlag = flag.Duration(
+		"push.interval",
+		15*time.Second,
+		"How often to push metrics to --push.remote-write-url.",
+	)
+	pushBearerTokenFileFlag = flag.String(
+		"push.bearer-token-file",
+		"",
+		"Path to a file containing a bearer token to send with each remote-write push.",
+	)
+)
+
+// runPusher periodically gathers reg and ships it to --push.remote-write-url
+// over Prometheus Remote Write, until stop is closed. main runs it in its own
+// goroutine alongside the /metrics HTTP handler, which is always served, for
+// hosts behind NAT that can't be scraped directly.
+func runPusher(reg *prometheus.Registry, stop <-chan struct{}) {
+	url := *pushRemoteWriteURLFlag
+	if url == "" {
+		return
+	}
+
+	ticker := time.NewTicker(*pushIntervalFlag)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			if err := pushOnce(reg, url); err != nil {
+				log.Printf("Error pushing metrics to %s: %v", url, err)
+			}
+		}
+	}
+}
+
+func pushOnce(reg *prometheus.Registry, url string) error {
+	families, err := reg.Gather()
+	if err != nil {
+		return fmt.Errorf("gathering metrics: %w", err)
+	}
+
+	req := &prompb.WriteRequest{
+		Timeseries: metricFamiliesToTimeseries(families),
+	}
+
+	// prompb is generated with gogo-protobuf, which bakes a Marshal method
+	// directly onto the message rather than implementing the newer
+	// protoreflect-based proto.Message; call it instead of proto.Marshal.
+	body, err := req.Marshal()
+	if err != nil {
+		return fmt.Errorf("marshaling write request: %w", err)
+	}
+
+	return sendRemoteWrite(url, snappy.Encode(nil, body))
+}
+
+func sendRemoteWrite(url string, body []byte) error {
+	httpReq, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	httpReq.Header.Set("Content-Type", "application/x-protobuf")
+	httpReq.Header.Set("Content-Encoding", "snappy")
+	httpReq.Header.Set("X-Prometheus-Remote-Write-Version", "0.1.0")
+
+	if token, err := bearerToken(); err != nil {
+		return err
+	} else if token != "" {
+		httpReq.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp, err := http.DefaultClient.Do(httpReq)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("remote write endpoint returned %s", resp.Status)
+	}
+	return nil
+}
+
+func bearerToken() (string, error) {
+	if *pushBearerTokenFileFlag == "" {
+		return "", nil
+	}
+
+	contents, err := os.ReadFile(*pushBearerTokenFileFlag)
+	if err != nil {
+		return "", fmt.Errorf("reading bearer token file: %w", err)
+	}
+	return strings.TrimSpace(string(contents)), nil
+}
+
+// metricFamiliesToTimeseries flattens gathered MetricFamilies into remote
+// write TimeSeries, one per metric (per label combination). Only Gauge,
+// Counter and Untyped metrics are supported; Summary and Histogram metrics
+// are not expanded into per-quantile/per-bucket series (none of this
+// binary's collectors emit those types yet, see metricValue).
+func metricFamiliesToTimeseries(families []*dto.MetricFamily) []prompb.TimeSeries {
+	now := timestampMillis()
+
+	var series []prompb.TimeSeries
+	for _, family := range families {
+		name := family.GetName()
+		for _, metric := range family.GetMetric() {
+			labels := []prompb.Label{{Name: "__name__", Value: name}}
+			for _, pair := range metric.GetLabel() {
+				labels = append(labels, prompb.Label{Name: pair.GetName(), Value: pair.GetValue()})
+			}
+
+			value := metricValue(metric)
+			series = append(series, prompb.TimeSeries{
+				Labels:  labels,
+				Samples: []prompb.Sample{{Value: value, Timestamp: now}},
+			})
+		}
+	}
+	return series
+}
+
+// metricValue returns metric's sample value. Summary and Histogram metrics
+// aren't handled (see the metricFamiliesToTimeseries doc comment) and fall
+// through to 0.
+func metricValue(metric *dto.Metric) float64 {
+	switch {
+	case metric.Gauge != nil:
+		return metric.Gauge.GetValue()
+	case metric.Counter != nil:
+		return metric.Counter.GetValue()
+	case metric.Untyped != nil:
+		return metric.Untyped.GetValue()
+	default:
+		return 0
+	}
+}
+
+func timestampMillis() int64 {
+	return time.Now().UnixNano() / int64(time.Millisecond)
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"testing"
+
+	dto "github.com/prometheus/client_model/go"
+)
+
+func TestMetricValue(t *testing.T) {
+	cases := []struct {
+		name   string
+		metric *dto.Metric
+		want   float64
+	}{
+		{"gauge", &dto.Metric{Gauge: &dto.Gauge{Value: floatPtr(1.5)}}, 1.5},
+		{"counter", &dto.Metric{Counter: &dto.Counter{Value: floatPtr(42)}}, 42},
+		{"untyped", &dto.Metric{Untyped: &dto.Untyped{Value: floatPtr(7)}}, 7},
+		{"unsupported", &dto.Metric{Summary: &dto.Summary{}}, 0},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := metricValue(c.metric); got != c.want {
+				t.Errorf("metricValue() = %v, want %v", got, c.want)
+			}
+		})
+	}
+}
+
+func TestMetricFamiliesToTimeseries(t *testing.T) {
+	families := []*dto.MetricFamily{
+		{
+			Name: strPtr("envirobly_ram_usage_percent"),
+			Metric: []*dto.Metric{
+				{Gauge: &dto.Gauge{Value: floatPtr(12.34)}},
+			},
+		},
+		{
+			Name: strPtr("envirobly_filesystem_usage_percent"),
+			Metric: []*dto.Metric{
+				{
+					Label: []*dto.LabelPair{
+						{Name: strPtr("mountpoint"), Value: strPtr("/")},
+					},
+					Gauge: &dto.Gauge{Value: floatPtr(55)},
+				},
+			},
+		},
+	}
+
+	series := metricFamiliesToTimeseries(families)
+	if len(series) != 2 {
+		t.Fatalf("got %d series, want 2", len(series))
+	}
+
+	withMountpoint := series[1]
+	if got, want := withMountpoint.Labels[0].Name, "__name__"; got != want {
+		t.Errorf("Labels[0].Name = %q, want %q", got, want)
+	}
+	if got, want := withMountpoint.Labels[1].Value, "/"; got != want {
+		t.Errorf("Labels[1].Value = %q, want %q", got, want)
+	}
+	if got, want := withMountpoint.Samples[0].Value, 55.0; got != want {
+		t.Errorf("Samples[0].Value = %v, want %v", got, want)
+	}
+}
+
+func floatPtr(v float64) *float64 { return &v }
+func strPtr(v string) *string     { return &v }
@@ -0,0 +1,8 @@
+package main
+
+import "math"
+
+// roundToTwoDecimals rounds a float64 to two decimal places.
+func roundToTwoDecimals(value float64) float64 {
+	return math.Round(value*100) / 100
+}